@@ -0,0 +1,125 @@
+// Command cancan replays and captures CAN bus dumps. See pkg/cancan for
+// the parsing, encoding, and replay/capture logic this binary wires flags
+// to.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/brutella/can"
+
+	"github.com/Ganderworks/cancan/pkg/cancan"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "capture" {
+		runCapture(os.Args[2:])
+		return
+	}
+
+	csvFile := flag.String("csv", "", "Path to CAN dump file (CSV or candump -L log)")
+	format := flag.String("format", "", "Dump format: csv or candump (default: auto-detect from file extension)")
+	canInterface := flag.String("can", "vcan0", "CAN interface name (e.g., vcan0, can0)")
+	loop := flag.Bool("loop", false, "Loop replay continuously")
+	speed := flag.Float64("speed", 1.0, "Playback speed multiplier (e.g. 0.5 for half speed, 10 for 10x)")
+	start := flag.Duration("start", 0, "Skip ahead to this offset (relative to the first frame) before replaying")
+	duration := flag.Duration("duration", 0, "Only replay frames within this long a window after -start (0 means no limit)")
+	filter := flag.String("filter", "", "Only replay frames with IDs in this comma-separated list of hex IDs/ranges (e.g. 100,7E0-7EF)")
+	exclude := flag.String("exclude", "", "Drop frames with IDs in this comma-separated list of hex IDs/ranges")
+	flag.Parse()
+
+	if *csvFile == "" {
+		log.Fatal("Please specify a dump file with -csv flag")
+	}
+
+	filterRanges, err := cancan.ParseIDRanges(*filter)
+	if err != nil {
+		log.Fatalf("Invalid -filter: %v", err)
+	}
+	excludeRanges, err := cancan.ParseIDRanges(*exclude)
+	if err != nil {
+		log.Fatalf("Invalid -exclude: %v", err)
+	}
+
+	dumpFormat := *format
+	if dumpFormat == "" {
+		dumpFormat = cancan.DetectFormat(*csvFile)
+	}
+
+	fmt.Printf("Opening dump file (%s format): %s\n", dumpFormat, *csvFile)
+	it, closer, err := cancan.OpenFile(*csvFile, dumpFormat)
+	if err != nil {
+		log.Fatalf("Failed to open dump: %v", err)
+	}
+	defer closer.Close()
+
+	fmt.Printf("Opening CAN interface: %s\n", *canInterface)
+	bus, err := can.NewBusForInterfaceWithName(*canInterface)
+	if err != nil {
+		log.Fatalf("Failed to open CAN interface: %v\nMake sure the interface exists (use 'ip link show' or create with 'sudo ip link add dev vcan0 type vcan')", err)
+	}
+	defer bus.Disconnect()
+
+	opts := cancan.ReplayOptions{
+		Loop:     *loop,
+		Speed:    *speed,
+		Start:    *start,
+		Duration: *duration,
+		Filter:   filterRanges,
+		Exclude:  excludeRanges,
+	}
+
+	fmt.Println("Starting replay with original timing...")
+	if err := cancan.ReplayFrames(bus, it, opts); err != nil {
+		log.Fatalf("Replay failed: %v", err)
+	}
+
+	fmt.Println("Replay completed successfully!")
+}
+
+func runCapture(args []string) {
+	fs := flag.NewFlagSet("capture", flag.ExitOnError)
+	out := fs.String("out", "", "Path to write the captured dump to")
+	format := fs.String("format", "candump", "Dump format to write: csv or candump")
+	canInterface := fs.String("can", "vcan0", "CAN interface name (e.g., vcan0, can0)")
+	duration := fs.Duration("duration", 0, "Stop capturing after this long (0 means no limit)")
+	maxFrames := fs.Int("max-frames", 0, "Stop capturing after this many frames (0 means no limit)")
+	fs.Parse(args)
+
+	if *out == "" {
+		log.Fatal("Please specify an output file with -out flag")
+	}
+
+	enc, err := cancan.EncoderForFormat(*format)
+	if err != nil {
+		log.Fatalf("Invalid -format: %v", err)
+	}
+
+	file, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("Failed to create output file: %v", err)
+	}
+	defer file.Close()
+	w := bufio.NewWriter(file)
+
+	fmt.Printf("Opening CAN interface: %s\n", *canInterface)
+	bus, err := can.NewBusForInterfaceWithName(*canInterface)
+	if err != nil {
+		log.Fatalf("Failed to open CAN interface: %v\nMake sure the interface exists (use 'ip link show' or create with 'sudo ip link add dev vcan0 type vcan')", err)
+	}
+
+	fmt.Printf("Capturing frames from %s to %s (press Ctrl+C to stop)...\n", *canInterface, *out)
+	opts := cancan.CaptureOptions{
+		Duration:  *duration,
+		MaxFrames: *maxFrames,
+	}
+	if err := cancan.CaptureFrames(bus, *canInterface, enc, w, opts); err != nil {
+		log.Fatalf("Capture failed: %v", err)
+	}
+
+	fmt.Println("Capture completed successfully!")
+}
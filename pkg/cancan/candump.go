@@ -0,0 +1,129 @@
+package cancan
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// candumpLineRE matches the standard `candump -L` log line format:
+// "(1700623093.260875) can0 7E0#0322128C00000000"
+var candumpLineRE = regexp.MustCompile(`^\(([\d\.]+)\)\s+(\S+)\s+(.+)$`)
+
+// candumpIterator streams CANFrame values out of a `candump -L` log file.
+type candumpIterator struct {
+	scanner *bufio.Scanner
+	lineNum int
+}
+
+// NewCandumpIterator returns a streaming FrameIterator over a `candump -L`
+// log.
+func NewCandumpIterator(r io.Reader) FrameIterator {
+	return &candumpIterator{scanner: bufio.NewScanner(r)}
+}
+
+func (it *candumpIterator) Next() (CANFrame, error) {
+	for it.scanner.Scan() {
+		it.lineNum++
+		line := strings.TrimSpace(it.scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		frame, err := parseCandumpLine(line)
+		if err != nil {
+			log.Printf("Warning: skipping line %d - %v", it.lineNum, err)
+			continue
+		}
+		return frame, nil
+	}
+	if err := it.scanner.Err(); err != nil {
+		return CANFrame{}, fmt.Errorf("failed to read candump log: %w", err)
+	}
+	return CANFrame{}, io.EOF
+}
+
+func parseCandumpLine(line string) (CANFrame, error) {
+	var frame CANFrame
+
+	matches := candumpLineRE.FindStringSubmatch(line)
+	if matches == nil {
+		return frame, fmt.Errorf("line does not match candump format: %q", line)
+	}
+	timeval, iface, frameField := matches[1], matches[2], matches[3]
+
+	secStr, usecStr, ok := strings.Cut(timeval, ".")
+	if !ok {
+		return frame, fmt.Errorf("invalid timeval: %q", timeval)
+	}
+	sec, err := strconv.ParseUint(secStr, 10, 64)
+	if err != nil {
+		return frame, fmt.Errorf("invalid timeval seconds: %w", err)
+	}
+	// Right-pad (or truncate) the fractional part to exactly 6 digits so it
+	// represents whole microseconds regardless of how many digits candump wrote.
+	usecStr = (usecStr + "000000")[:6]
+	usec, err := strconv.ParseUint(usecStr, 10, 64)
+	if err != nil {
+		return frame, fmt.Errorf("invalid timeval microseconds: %w", err)
+	}
+	frame.Timestamp = sec*1e6 + usec
+	frame.Interface = iface
+
+	idField, dataField, ok := strings.Cut(frameField, "#")
+	if !ok {
+		return frame, fmt.Errorf("invalid frame field: %q", frameField)
+	}
+
+	// CAN-FD frames use "ID##FLAGSDATA" - strip the single-hex-digit flags
+	// nibble and keep going.
+	if rest, isFD := strings.CutPrefix(dataField, "#"); isFD {
+		if len(rest) < 1 {
+			return frame, fmt.Errorf("invalid CAN-FD frame field: %q", frameField)
+		}
+		dataField = rest[1:]
+	}
+
+	id, err := strconv.ParseUint(idField, 16, 32)
+	if err != nil {
+		return frame, fmt.Errorf("invalid CAN ID: %w", err)
+	}
+	frame.ID = uint32(id)
+	frame.Extended = len(idField) > 3
+
+	data, err := hex.DecodeString(dataField)
+	if err != nil {
+		return frame, fmt.Errorf("invalid frame data: %w", err)
+	}
+	frame.Data = data
+	frame.Length = uint8(len(data))
+
+	return frame, nil
+}
+
+// CandumpEncoder writes the `candump -L` log line format that
+// NewCandumpIterator reads: "(sec.usec) iface ID#DATA".
+type CandumpEncoder struct{}
+
+func (e *CandumpEncoder) Encode(w io.Writer, frame CANFrame) error {
+	sec := frame.Timestamp / 1e6
+	usec := frame.Timestamp % 1e6
+
+	idWidth := 3
+	if frame.Extended {
+		idWidth = 8
+	}
+
+	iface := frame.Interface
+	if iface == "" {
+		iface = "can0"
+	}
+
+	_, err := fmt.Fprintf(w, "(%d.%06d) %s %0*X#%X\n", sec, usec, iface, idWidth, frame.ID, frame.Data)
+	return err
+}
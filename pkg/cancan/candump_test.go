@@ -0,0 +1,97 @@
+package cancan
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestParseCandumpLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		line     string
+		wantID   uint32
+		wantExt  bool
+		wantData []byte
+		wantErr  bool
+	}{
+		{
+			name:     "standard frame",
+			line:     "(1700623093.260875) can0 7E0#0322128C00000000",
+			wantID:   0x7E0,
+			wantExt:  false,
+			wantData: []byte{0x03, 0x22, 0x12, 0x8C, 0x00, 0x00, 0x00, 0x00},
+		},
+		{
+			name:     "extended frame",
+			line:     "(1700623093.260875) can0 1A2B3C4D#AABB",
+			wantID:   0x1A2B3C4D,
+			wantExt:  true,
+			wantData: []byte{0xAA, 0xBB},
+		},
+		{
+			name:     "CAN-FD frame",
+			line:     "(1700623093.260875) can0 123##1AABBCCDDEEFF0011",
+			wantID:   0x123,
+			wantExt:  false,
+			wantData: []byte{0xAA, 0xBB, 0xCC, 0xDD, 0xEE, 0xFF, 0x00, 0x11},
+		},
+		{
+			name:    "malformed line",
+			line:    "not a candump line",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame, err := parseCandumpLine(tt.line)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got frame %+v", frame)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if frame.ID != tt.wantID {
+				t.Errorf("ID = 0x%X, want 0x%X", frame.ID, tt.wantID)
+			}
+			if frame.Extended != tt.wantExt {
+				t.Errorf("Extended = %v, want %v", frame.Extended, tt.wantExt)
+			}
+			if string(frame.Data) != string(tt.wantData) {
+				t.Errorf("Data = % X, want % X", frame.Data, tt.wantData)
+			}
+		})
+	}
+}
+
+func TestNewCandumpIteratorSkipsBadLines(t *testing.T) {
+	log := "(1700623093.260875) can0 7E0#01\n" +
+		"garbage\n" +
+		"(1700623093.261000) can0 123##1AABB\n"
+
+	it := NewCandumpIterator(strings.NewReader(log))
+
+	frame, err := it.Next()
+	if err != nil {
+		t.Fatalf("unexpected error on first frame: %v", err)
+	}
+	if frame.ID != 0x7E0 {
+		t.Errorf("first frame ID = 0x%X, want 0x7E0", frame.ID)
+	}
+
+	frame, err = it.Next()
+	if err != nil {
+		t.Fatalf("unexpected error on FD frame: %v", err)
+	}
+	if frame.ID != 0x123 || string(frame.Data) != string([]byte{0xAA, 0xBB}) {
+		t.Errorf("FD frame = %+v, want ID=0x123 Data=AABB", frame)
+	}
+
+	if _, err := it.Next(); err != io.EOF {
+		t.Errorf("expected io.EOF, got %v", err)
+	}
+}
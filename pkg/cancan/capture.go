@@ -0,0 +1,137 @@
+package cancan
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/brutella/can"
+)
+
+// Encoder serializes a CANFrame into one of the dump formats the replay
+// path can read back. New formats (e.g. ASC, BLF) can be added by
+// implementing this interface.
+type Encoder interface {
+	Encode(w io.Writer, frame CANFrame) error
+}
+
+// EncoderForFormat returns the Encoder for format ("csv" or "candump").
+func EncoderForFormat(format string) (Encoder, error) {
+	switch format {
+	case "candump":
+		return &CandumpEncoder{}, nil
+	case "csv":
+		return &CSVEncoder{}, nil
+	default:
+		return nil, fmt.Errorf("unknown format %q (expected csv or candump)", format)
+	}
+}
+
+// CaptureOptions bounds how long and how much CaptureFrames records
+// before stopping on its own, in addition to a SIGINT from the user.
+type CaptureOptions struct {
+	Duration  time.Duration
+	MaxFrames int
+}
+
+// CaptureFrames subscribes to bus and encodes every frame it sees to w
+// until opts.Duration or opts.MaxFrames is reached, or the process
+// receives SIGINT. It waits for the subscribed handler to fully stop
+// before flushing w, so the flush never races an in-flight Encode.
+func CaptureFrames(bus *can.Bus, iface string, enc Encoder, w *bufio.Writer, opts CaptureOptions) error {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT)
+	defer signal.Stop(sigCh)
+
+	var count int
+	done := make(chan struct{})
+	var closeDone sync.Once
+	stop := func() { closeDone.Do(func() { close(done) }) }
+
+	stopped := make(chan struct{})
+	var encodeErr error
+
+	bus.SubscribeFunc(func(frm can.Frame) {
+		select {
+		case <-done:
+			return
+		default:
+		}
+
+		extended := frm.ID&can.MaskEff != 0
+		id := frm.ID & can.MaskIDSff
+		if extended {
+			id = frm.ID & can.MaskIDEff
+		}
+
+		frame := CANFrame{
+			Timestamp: uint64(time.Now().UnixMicro()),
+			ID:        id,
+			Extended:  extended,
+			Data:      append([]byte(nil), frm.Data[:frm.Length]...),
+			Length:    frm.Length,
+			Interface: iface,
+		}
+
+		if err := enc.Encode(w, frame); err != nil {
+			encodeErr = err
+			stop()
+			return
+		}
+
+		count++
+		if opts.MaxFrames > 0 && count >= opts.MaxFrames {
+			fmt.Printf("Reached -max-frames (%d), stopping capture\n", opts.MaxFrames)
+			stop()
+		}
+	})
+
+	// disconnecting is closed right before we call bus.Disconnect() below,
+	// so the goroutine can tell a deliberate shutdown (expected to surface
+	// as a read error) apart from the bus actually failing.
+	disconnecting := make(chan struct{})
+
+	// ConnectAndPublish runs the handler above inline as it reads frames,
+	// so this goroutine is the only writer of w and encodeErr until it
+	// exits; closing stopped gives the caller a happens-before point to
+	// read them safely after bus.Disconnect() forces it to return.
+	go func() {
+		defer close(stopped)
+		if err := bus.ConnectAndPublish(); err != nil {
+			select {
+			case <-disconnecting:
+				// Expected: we asked the bus to disconnect.
+			default:
+				encodeErr = err
+				stop()
+			}
+		}
+	}()
+
+	var timeout <-chan time.Time
+	if opts.Duration > 0 {
+		timer := time.NewTimer(opts.Duration)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case <-done:
+	case <-timeout:
+		fmt.Println("Reached -duration, stopping capture")
+	case <-sigCh:
+		fmt.Println("Received interrupt, stopping capture")
+	}
+
+	close(disconnecting)
+	bus.Disconnect()
+	<-stopped
+
+	w.Flush()
+	return encodeErr
+}
@@ -0,0 +1,125 @@
+package cancan
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/brutella/can"
+)
+
+// fakeBusConn is a minimal can.ReadWriteCloser whose ReadFrame unblocks
+// with a non-io.EOF error once Close is called, mirroring how a closed OS
+// socket behaves (and unlike can.NewEchoReadWriteCloser's test fake,
+// which spins forever on a closed, drained buffer).
+type fakeBusConn struct {
+	mu     sync.Mutex
+	frames chan can.Frame
+	closed bool
+}
+
+func newFakeBusConn() *fakeBusConn {
+	return &fakeBusConn{frames: make(chan can.Frame, 256)}
+}
+
+func (c *fakeBusConn) Read(b []byte) (int, error) { return 0, io.EOF }
+
+func (c *fakeBusConn) ReadFrame(frame *can.Frame) error {
+	frm, ok := <-c.frames
+	if !ok {
+		return io.ErrClosedPipe
+	}
+	*frame = frm
+	return nil
+}
+
+func (c *fakeBusConn) Write(b []byte) (int, error) { return len(b), nil }
+
+func (c *fakeBusConn) WriteFrame(frame can.Frame) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return io.ErrClosedPipe
+	}
+	c.frames <- frame
+	return nil
+}
+
+func (c *fakeBusConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		close(c.frames)
+	}
+	return nil
+}
+
+// TestCaptureFramesStopsOnDurationWithoutRace exercises the path where
+// -duration fires while frames are still arriving: CaptureFrames must
+// wait for the handler goroutine to fully stop before flushing w, or
+// `go test -race` flags a write/flush race on w and encodeErr.
+func TestCaptureFramesStopsOnDurationWithoutRace(t *testing.T) {
+	conn := newFakeBusConn()
+	bus := can.NewBus(conn)
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 1000; i++ {
+			if conn.WriteFrame(can.Frame{ID: uint32(i % 0x7FF), Length: 1, Data: [8]byte{byte(i)}}) != nil {
+				return
+			}
+		}
+	}()
+
+	opts := CaptureOptions{Duration: 20 * time.Millisecond}
+	if err := CaptureFrames(bus, "vcan0", &CandumpEncoder{}, w, opts); err != nil {
+		t.Fatalf("CaptureFrames returned error: %v", err)
+	}
+
+	<-done
+
+	if buf.Len() == 0 {
+		t.Fatal("expected at least one frame to be captured before -duration elapsed")
+	}
+}
+
+func TestCaptureFramesStopsOnMaxFrames(t *testing.T) {
+	conn := newFakeBusConn()
+	bus := can.NewBus(conn)
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	go func() {
+		for i := 0; i < 10; i++ {
+			if conn.WriteFrame(can.Frame{ID: 0x100, Length: 1, Data: [8]byte{byte(i)}}) != nil {
+				return
+			}
+		}
+	}()
+
+	opts := CaptureOptions{MaxFrames: 3}
+	if err := CaptureFrames(bus, "vcan0", &CandumpEncoder{}, w, opts); err != nil {
+		t.Fatalf("CaptureFrames returned error: %v", err)
+	}
+
+	it := NewCandumpIterator(bytes.NewReader(buf.Bytes()))
+	var count int
+	for {
+		if _, err := it.Next(); err != nil {
+			break
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("captured %d frames, want 3", count)
+	}
+}
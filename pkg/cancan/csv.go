@@ -0,0 +1,132 @@
+package cancan
+
+import (
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// csvIterator streams CANFrame values out of the CSV schema written by
+// CSVEncoder: a header row followed by
+// Timestamp,ID,Extended,RTR,Bus,Length,D1..D8 rows.
+type csvIterator struct {
+	r       *csv.Reader
+	lineNum int
+}
+
+// NewCSVIterator returns a streaming FrameIterator over the CSV schema,
+// skipping the header row.
+func NewCSVIterator(r io.Reader) (FrameIterator, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	if _, err := cr.Read(); err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+
+	return &csvIterator{r: cr, lineNum: 1}, nil
+}
+
+func (it *csvIterator) Next() (CANFrame, error) {
+	for {
+		record, err := it.r.Read()
+		if err == io.EOF {
+			return CANFrame{}, io.EOF
+		}
+		if err != nil {
+			return CANFrame{}, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		it.lineNum++
+
+		if len(record) < 12 {
+			log.Printf("Warning: skipping row %d - insufficient columns", it.lineNum)
+			continue
+		}
+
+		frame, err := parseCANFrame(record)
+		if err != nil {
+			log.Printf("Warning: skipping row %d - %v", it.lineNum, err)
+			continue
+		}
+		return frame, nil
+	}
+}
+
+func parseCANFrame(record []string) (CANFrame, error) {
+	var frame CANFrame
+
+	// Parse timestamp
+	timestamp, err := strconv.ParseUint(record[0], 10, 64)
+	if err != nil {
+		return frame, fmt.Errorf("invalid timestamp: %w", err)
+	}
+	frame.Timestamp = timestamp
+
+	// Parse CAN ID (remove leading zeros)
+	idStr := strings.TrimPrefix(record[1], "0x")
+	idStr = strings.TrimLeft(idStr, "0")
+	if idStr == "" {
+		idStr = "0"
+	}
+	id, err := strconv.ParseUint(idStr, 16, 32)
+	if err != nil {
+		return frame, fmt.Errorf("invalid CAN ID: %w", err)
+	}
+	frame.ID = uint32(id)
+
+	// Parse extended flag
+	frame.Extended = strings.ToLower(record[2]) == "true"
+
+	// Parse length
+	length, err := strconv.ParseUint(record[5], 10, 8)
+	if err != nil {
+		return frame, fmt.Errorf("invalid length: %w", err)
+	}
+	frame.Length = uint8(length)
+
+	// Parse data bytes (D1-D8)
+	frame.Data = make([]byte, frame.Length)
+	for i := 0; i < int(frame.Length) && i < 8; i++ {
+		dataStr := strings.TrimSpace(record[6+i])
+		dataStr = strings.TrimSuffix(dataStr, ",")
+
+		b, err := hex.DecodeString(dataStr)
+		if err != nil || len(b) != 1 {
+			return frame, fmt.Errorf("invalid data byte D%d: %s", i+1, dataStr)
+		}
+		frame.Data[i] = b[0]
+	}
+
+	return frame, nil
+}
+
+// CSVEncoder writes the same CSV schema parseCANFrame expects, including
+// the header row on the first call.
+type CSVEncoder struct {
+	wroteHeader bool
+}
+
+func (e *CSVEncoder) Encode(w io.Writer, frame CANFrame) error {
+	if !e.wroteHeader {
+		if _, err := io.WriteString(w, "Timestamp,ID,Extended,RTR,Bus,Length,D1,D2,D3,D4,D5,D6,D7,D8\n"); err != nil {
+			return err
+		}
+		e.wroteHeader = true
+	}
+
+	var data [8]string
+	for i := range data {
+		if i < len(frame.Data) {
+			data[i] = fmt.Sprintf("%02X", frame.Data[i])
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d,0x%X,%t,,,%d,%s,%s,%s,%s,%s,%s,%s,%s\n",
+		frame.Timestamp, frame.ID, frame.Extended, frame.Length,
+		data[0], data[1], data[2], data[3], data[4], data[5], data[6], data[7])
+	return err
+}
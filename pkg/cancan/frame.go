@@ -0,0 +1,18 @@
+// Package cancan provides frame parsing, encoding, and replay/capture
+// helpers for CAN bus dumps, shared by the cmd/cancan binary and any other
+// Go program that wants to work with the same dump formats.
+package cancan
+
+// CANFrame is a single CAN frame read from (or about to be written to) a
+// dump file.
+type CANFrame struct {
+	// Timestamp is microseconds. candump logs and CaptureFrames output (in
+	// either format) count from the Unix epoch; other CSV dumps may count
+	// from the start of their capture.
+	Timestamp uint64
+	ID        uint32
+	Extended  bool
+	Data      []byte
+	Length    uint8
+	Interface string
+}
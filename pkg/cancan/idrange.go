@@ -0,0 +1,59 @@
+package cancan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// IDRange is an inclusive CAN ID range used by -filter/-exclude, e.g.
+// 0x100-0x1FF. A single ID is represented as Lo == Hi.
+type IDRange struct {
+	Lo uint32
+	Hi uint32
+}
+
+func (r IDRange) contains(id uint32) bool {
+	return id >= r.Lo && id <= r.Hi
+}
+
+// ParseIDRanges parses a comma-separated list of hex IDs and/or hex
+// ranges, e.g. "100,7E0-7EF,0x123".
+func ParseIDRanges(s string) ([]IDRange, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var ranges []IDRange
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		loStr, hiStr, isRange := strings.Cut(part, "-")
+		lo, err := strconv.ParseUint(strings.TrimPrefix(loStr, "0x"), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CAN ID %q: %w", loStr, err)
+		}
+		hi := lo
+		if isRange {
+			hi, err = strconv.ParseUint(strings.TrimPrefix(hiStr, "0x"), 16, 32)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CAN ID %q: %w", hiStr, err)
+			}
+		}
+		ranges = append(ranges, IDRange{Lo: uint32(lo), Hi: uint32(hi)})
+	}
+
+	return ranges, nil
+}
+
+func idRangesContain(ranges []IDRange, id uint32) bool {
+	for _, r := range ranges {
+		if r.contains(id) {
+			return true
+		}
+	}
+	return false
+}
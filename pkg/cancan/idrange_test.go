@@ -0,0 +1,89 @@
+package cancan
+
+import "testing"
+
+func TestParseIDRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []IDRange
+		wantErr bool
+	}{
+		{name: "empty", input: "", want: nil},
+		{
+			name:  "single ID",
+			input: "100",
+			want:  []IDRange{{Lo: 0x100, Hi: 0x100}},
+		},
+		{
+			name:  "single ID with 0x prefix",
+			input: "0x1A",
+			want:  []IDRange{{Lo: 0x1A, Hi: 0x1A}},
+		},
+		{
+			name:  "range",
+			input: "7E0-7EF",
+			want:  []IDRange{{Lo: 0x7E0, Hi: 0x7EF}},
+		},
+		{
+			name:  "mixed list with spaces",
+			input: "100, 7E0-7EF, 0x123",
+			want: []IDRange{
+				{Lo: 0x100, Hi: 0x100},
+				{Lo: 0x7E0, Hi: 0x7EF},
+				{Lo: 0x123, Hi: 0x123},
+			},
+		},
+		{name: "invalid hex", input: "ZZZ", wantErr: true},
+		{name: "invalid range bound", input: "100-ZZZ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseIDRanges(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %+v, want %+v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("range %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestIDRangesContain(t *testing.T) {
+	ranges := []IDRange{{Lo: 0x100, Hi: 0x100}, {Lo: 0x7E0, Hi: 0x7EF}}
+
+	tests := []struct {
+		id   uint32
+		want bool
+	}{
+		{id: 0x100, want: true},
+		{id: 0x7E5, want: true},
+		{id: 0x7E0, want: true},
+		{id: 0x7EF, want: true},
+		{id: 0x7F0, want: false},
+		{id: 0x0, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := idRangesContain(ranges, tt.id); got != tt.want {
+			t.Errorf("idRangesContain(_, 0x%X) = %v, want %v", tt.id, got, tt.want)
+		}
+	}
+
+	if idRangesContain(nil, 0x100) {
+		t.Error("idRangesContain(nil, _) should be false")
+	}
+}
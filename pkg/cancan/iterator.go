@@ -0,0 +1,165 @@
+package cancan
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FrameIterator streams CANFrame values one at a time, so large dumps can
+// be replayed without holding the whole file in memory. Next returns
+// io.EOF once the underlying source is exhausted.
+type FrameIterator interface {
+	Next() (CANFrame, error)
+}
+
+// Resettable is implemented by iterators that can rewind to the start of
+// their source, so ReplayFrames can support -loop without re-opening the
+// file from outside.
+type Resettable interface {
+	Reset() error
+}
+
+// DetectFormat chooses a parser based on file extension, falling back to
+// the CSV schema when the extension is unrecognized.
+func DetectFormat(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".log":
+		return "candump"
+	default:
+		return "csv"
+	}
+}
+
+// NewIterator returns a streaming FrameIterator for format ("csv" or
+// "candump") reading from r.
+func NewIterator(format string, r io.Reader) (FrameIterator, error) {
+	switch format {
+	case "candump":
+		return NewCandumpIterator(r), nil
+	case "csv":
+		return NewCSVIterator(r)
+	default:
+		return nil, fmt.Errorf("unknown format %q (expected csv or candump)", format)
+	}
+}
+
+// OpenFile opens filename and returns a FrameIterator over it along with
+// an io.Closer the caller must Close when done. If the iterator supports
+// -loop it implements Resettable.
+func OpenFile(filename, format string) (FrameIterator, io.Closer, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open %s: %w", filename, err)
+	}
+
+	it, err := NewResettableIterator(file, format)
+	if err != nil {
+		file.Close()
+		return nil, nil, err
+	}
+
+	return it, file, nil
+}
+
+// ResettableIterator wraps a FrameIterator so it can be rewound for -loop.
+// It rewinds the underlying source directly when the source is an
+// io.ReadSeeker (e.g. a regular file); otherwise NewResettableIterator
+// copies the whole source to a temp file before returning, so
+// non-seekable sources (pipes, stdin) can still be looped.
+type ResettableIterator struct {
+	cur     FrameIterator
+	format  string
+	seeker  io.ReadSeeker
+	tmpFile *os.File
+}
+
+// NewResettableIterator builds a ResettableIterator for format over r.
+func NewResettableIterator(r io.Reader, format string) (*ResettableIterator, error) {
+	ri := &ResettableIterator{format: format}
+
+	if seeker, ok := seekableReader(r); ok {
+		ri.seeker = seeker
+	} else {
+		tmp, err := os.CreateTemp("", "cancan-loop-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to buffer non-seekable source for -loop: %w", err)
+		}
+		if _, err := io.Copy(tmp, r); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return nil, fmt.Errorf("failed to buffer non-seekable source for -loop: %w", err)
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+			return nil, err
+		}
+		ri.tmpFile = tmp
+		ri.seeker = tmp
+	}
+
+	it, err := NewIterator(format, ri.seeker)
+	if err != nil {
+		ri.closeTemp()
+		return nil, err
+	}
+	ri.cur = it
+
+	return ri, nil
+}
+
+// seekableReader reports whether r can actually be rewound. A type
+// assertion to io.ReadSeeker isn't enough: *os.File implements Seek
+// regardless of whether the underlying fd supports it, so os.Stdin and
+// FIFOs opened with os.Open assert true but fail at Seek time with
+// "illegal seek". Seeking 0 bytes relative to the current offset costs
+// nothing on a real seekable source and doesn't consume any input on a
+// non-seekable one, so it's safe to use as the actual probe.
+func seekableReader(r io.Reader) (io.ReadSeeker, bool) {
+	seeker, ok := r.(io.ReadSeeker)
+	if !ok {
+		return nil, false
+	}
+	if _, err := seeker.Seek(0, io.SeekCurrent); err != nil {
+		return nil, false
+	}
+	return seeker, true
+}
+
+func (ri *ResettableIterator) Next() (CANFrame, error) {
+	return ri.cur.Next()
+}
+
+// Reset rewinds the iterator back to the start of its source.
+func (ri *ResettableIterator) Reset() error {
+	if _, err := ri.seeker.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to rewind for -loop: %w", err)
+	}
+	it, err := NewIterator(ri.format, ri.seeker)
+	if err != nil {
+		return err
+	}
+	ri.cur = it
+	return nil
+}
+
+// Close releases any temp file created to buffer a non-seekable source.
+// It does not close the original source file - callers that opened one
+// (e.g. via OpenFile) are responsible for that themselves.
+func (ri *ResettableIterator) Close() error {
+	return ri.closeTemp()
+}
+
+func (ri *ResettableIterator) closeTemp() error {
+	if ri.tmpFile == nil {
+		return nil
+	}
+	path := ri.tmpFile.Name()
+	if err := ri.tmpFile.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
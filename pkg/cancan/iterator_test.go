@@ -0,0 +1,89 @@
+package cancan
+
+import (
+	"os"
+	"testing"
+)
+
+const candumpFixture = "(1700623093.000000) can0 100#0102\n(1700623093.100000) can0 200#0304\n"
+
+func TestResettableIteratorRewindsRegularFile(t *testing.T) {
+	tmp, err := os.CreateTemp("", "cancan-test-*.log")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := tmp.WriteString(candumpFixture); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tmp.Seek(0, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	ri, err := NewResettableIterator(tmp, "candump")
+	if err != nil {
+		t.Fatalf("NewResettableIterator: %v", err)
+	}
+
+	readAllIDs(t, ri, []uint32{0x100, 0x200})
+
+	if err := ri.Reset(); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	readAllIDs(t, ri, []uint32{0x100, 0x200})
+}
+
+// TestResettableIteratorBuffersNonSeekableSource reproduces the bug where
+// a *os.File backed by a pipe (non-seekable, like os.Stdin or a FIFO)
+// type-asserts to io.ReadSeeker but fails at Seek time. The resettable
+// iterator must fall back to buffering it to a temp file instead of
+// failing at Reset() with an "illegal seek" error.
+func TestResettableIteratorBuffersNonSeekableSource(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	go func() {
+		defer w.Close()
+		w.WriteString(candumpFixture)
+	}()
+
+	ri, err := NewResettableIterator(r, "candump")
+	if err != nil {
+		t.Fatalf("NewResettableIterator: %v", err)
+	}
+	defer ri.Close()
+
+	readAllIDs(t, ri, []uint32{0x100, 0x200})
+
+	if err := ri.Reset(); err != nil {
+		t.Fatalf("Reset on a buffered non-seekable source should succeed, got: %v", err)
+	}
+	readAllIDs(t, ri, []uint32{0x100, 0x200})
+}
+
+func readAllIDs(t *testing.T, it FrameIterator, want []uint32) {
+	t.Helper()
+
+	var got []uint32
+	for {
+		frame, err := it.Next()
+		if err != nil {
+			break
+		}
+		got = append(got, frame.ID)
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d frames %v, want %d frames %v", len(got), got, len(want), want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("frame %d ID = 0x%X, want 0x%X", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package cancan
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/brutella/can"
+)
+
+// ReplayOptions controls how ReplayFrames paces and filters a dump.
+type ReplayOptions struct {
+	// Loop replays the dump repeatedly. It requires an iterator that
+	// implements Resettable (e.g. one returned by OpenFile).
+	Loop bool
+
+	// Speed scales playback relative to the original timing: 0.5 is half
+	// speed, 10 is 10x. Zero is treated as 1 (real-time).
+	Speed float64
+
+	// Start and Duration, if non-zero, restrict replay to a window relative
+	// to the first frame's timestamp. Frames outside the window are
+	// skipped, but inter-frame delays among the surviving frames still
+	// reflect their original wall-clock gaps.
+	Start    time.Duration
+	Duration time.Duration
+
+	// Filter, if non-empty, replays only frames whose ID falls in one of
+	// these ranges. Exclude drops frames whose ID falls in one of these
+	// ranges, applied after Filter.
+	Filter  []IDRange
+	Exclude []IDRange
+}
+
+func (o ReplayOptions) speedOrDefault() float64 {
+	if o.Speed <= 0 {
+		return 1
+	}
+	return o.Speed
+}
+
+// ReplayFrames sends every frame it produces to bus, pacing publishes
+// according to each frame's original timestamp (scaled by opts.Speed) and
+// skipping frames outside opts.Filter/Exclude/Start/Duration. Because it
+// consumes a FrameIterator rather than a slice, multi-gigabyte dumps
+// replay in constant memory.
+func ReplayFrames(bus *can.Bus, it FrameIterator, opts ReplayOptions) error {
+	resettable, _ := it.(Resettable)
+	if opts.Loop && resettable == nil {
+		return fmt.Errorf("-loop requires a resettable iterator")
+	}
+
+	speed := opts.speedOrDefault()
+
+	for {
+		sent, err := replayOnce(bus, it, speed, opts)
+		if err != nil {
+			return err
+		}
+		if sent == 0 {
+			return fmt.Errorf("no frames to replay")
+		}
+
+		if !opts.Loop {
+			break
+		}
+
+		fmt.Println("Looping replay...")
+		if err := resettable.Reset(); err != nil {
+			return fmt.Errorf("failed to reset for -loop: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func replayOnce(bus *can.Bus, it FrameIterator, speed float64, opts ReplayOptions) (int, error) {
+	var (
+		sent           int
+		firstTimestamp uint64
+		prevTimestamp  uint64
+		endUs          uint64
+		haveFirst      bool
+	)
+	startUs := uint64(opts.Start / time.Microsecond)
+	hasEnd := opts.Duration > 0
+
+	for {
+		frame, err := it.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return sent, err
+		}
+
+		if !haveFirst {
+			firstTimestamp = frame.Timestamp
+			haveFirst = true
+			if hasEnd {
+				endUs = startUs + uint64(opts.Duration/time.Microsecond)
+			}
+		}
+
+		if len(opts.Filter) > 0 && !idRangesContain(opts.Filter, frame.ID) {
+			continue
+		}
+		if idRangesContain(opts.Exclude, frame.ID) {
+			continue
+		}
+
+		elapsed := frame.Timestamp - firstTimestamp
+		if elapsed < startUs {
+			continue
+		}
+		if hasEnd && elapsed >= endUs {
+			continue
+		}
+
+		var delay time.Duration
+		if sent > 0 {
+			deltaUs := frame.Timestamp - prevTimestamp
+			delay = time.Duration(float64(deltaUs)/speed) * time.Microsecond
+		}
+		if delay > 0 {
+			time.Sleep(delay)
+		}
+
+		canFrame := can.Frame{
+			ID:     frame.ID,
+			Length: frame.Length,
+		}
+		if frame.Extended {
+			canFrame.ID |= can.MaskEff
+		}
+		copy(canFrame.Data[:], frame.Data)
+
+		if err := bus.Publish(canFrame); err != nil {
+			return sent, fmt.Errorf("failed to send frame %d (ID: 0x%X): %w", sent, frame.ID, err)
+		}
+
+		prevTimestamp = frame.Timestamp
+		sent++
+		if sent%100 == 1 {
+			elapsed := time.Duration(frame.Timestamp-firstTimestamp) * time.Microsecond
+			fmt.Printf("Sent frame %d (ID: 0x%03X) at +%v\n", sent, frame.ID, elapsed)
+		}
+	}
+
+	return sent, nil
+}
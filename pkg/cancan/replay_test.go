@@ -0,0 +1,215 @@
+package cancan
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/brutella/can"
+)
+
+// sliceIterator is an in-memory, resettable FrameIterator used to drive
+// ReplayFrames in tests without touching the filesystem.
+type sliceIterator struct {
+	frames []CANFrame
+	i      int
+}
+
+func (it *sliceIterator) Next() (CANFrame, error) {
+	if it.i >= len(it.frames) {
+		return CANFrame{}, io.EOF
+	}
+	f := it.frames[it.i]
+	it.i++
+	return f, nil
+}
+
+func (it *sliceIterator) Reset() error {
+	it.i = 0
+	return nil
+}
+
+// recordingConn is a can.ReadWriteCloser that records every frame
+// published to it and never produces a frame to read (ReplayFrames never
+// reads from the bus, so ReadFrame is unused).
+type recordingConn struct {
+	sent []can.Frame
+}
+
+func (c *recordingConn) Read(b []byte) (int, error)   { return 0, io.EOF }
+func (c *recordingConn) ReadFrame(f *can.Frame) error { return io.EOF }
+func (c *recordingConn) Write(b []byte) (int, error)  { return len(b), nil }
+func (c *recordingConn) Close() error                 { return nil }
+func (c *recordingConn) WriteFrame(f can.Frame) error {
+	c.sent = append(c.sent, f)
+	return nil
+}
+
+func TestReplayFramesAppliesFilterAndExclude(t *testing.T) {
+	frames := []CANFrame{
+		{Timestamp: 0, ID: 0x100, Length: 0},
+		{Timestamp: 1000, ID: 0x200, Length: 0},
+		{Timestamp: 2000, ID: 0x7E5, Length: 0},
+		{Timestamp: 3000, ID: 0x300, Length: 0},
+	}
+
+	conn := &recordingConn{}
+	bus := can.NewBus(conn)
+
+	opts := ReplayOptions{
+		Filter:  []IDRange{{Lo: 0x100, Hi: 0x7FF}},
+		Exclude: []IDRange{{Lo: 0x200, Hi: 0x200}},
+	}
+
+	if err := ReplayFrames(bus, &sliceIterator{frames: frames}, opts); err != nil {
+		t.Fatalf("ReplayFrames: %v", err)
+	}
+
+	var gotIDs []uint32
+	for _, f := range conn.sent {
+		gotIDs = append(gotIDs, f.ID)
+	}
+	want := []uint32{0x100, 0x7E5, 0x300}
+	if len(gotIDs) != len(want) {
+		t.Fatalf("got IDs %v, want %v", gotIDs, want)
+	}
+	for i := range want {
+		if gotIDs[i] != want[i] {
+			t.Errorf("sent[%d] ID = 0x%X, want 0x%X", i, gotIDs[i], want[i])
+		}
+	}
+}
+
+func TestReplayFramesAppliesStartDurationWindow(t *testing.T) {
+	// Anchor (firstTimestamp) is the very first frame read, 0us, even
+	// though that frame itself falls outside the -start window below.
+	frames := []CANFrame{
+		{Timestamp: 0, ID: 0x1},
+		{Timestamp: 1000, ID: 0x2},
+		{Timestamp: 5000, ID: 0x3},
+		{Timestamp: 9000, ID: 0x4},
+	}
+
+	conn := &recordingConn{}
+	bus := can.NewBus(conn)
+
+	opts := ReplayOptions{
+		Start:    4 * time.Millisecond,
+		Duration: 4 * time.Millisecond,
+	}
+
+	if err := ReplayFrames(bus, &sliceIterator{frames: frames}, opts); err != nil {
+		t.Fatalf("ReplayFrames: %v", err)
+	}
+
+	if len(conn.sent) != 1 || conn.sent[0].ID != 0x3 {
+		t.Fatalf("got %+v, want only ID 0x3", conn.sent)
+	}
+}
+
+func TestReplayFramesSetsExtendedFlagOnID(t *testing.T) {
+	frames := []CANFrame{{Timestamp: 0, ID: 0x1ABCDEF, Extended: true}}
+
+	conn := &recordingConn{}
+	bus := can.NewBus(conn)
+
+	if err := ReplayFrames(bus, &sliceIterator{frames: frames}, ReplayOptions{}); err != nil {
+		t.Fatalf("ReplayFrames: %v", err)
+	}
+
+	if len(conn.sent) != 1 {
+		t.Fatalf("got %d frames sent, want 1", len(conn.sent))
+	}
+	if conn.sent[0].ID&can.MaskEff == 0 {
+		t.Error("expected EFF bit to be set on the published frame's ID")
+	}
+	if conn.sent[0].ID&can.MaskIDEff != 0x1ABCDEF {
+		t.Errorf("published ID bits = 0x%X, want 0x1ABCDEF", conn.sent[0].ID&can.MaskIDEff)
+	}
+}
+
+func TestReplayOnceReplaysAgainAfterReset(t *testing.T) {
+	frames := []CANFrame{{Timestamp: 0, ID: 0x1}, {Timestamp: 1000, ID: 0x2}}
+
+	conn := &recordingConn{}
+	bus := can.NewBus(conn)
+	it := &sliceIterator{frames: frames}
+
+	// Loop relies on Reset(), so replay the dump twice by driving
+	// replayOnce directly and resetting in between - ReplayFrames itself
+	// would loop forever without an external stop condition.
+	sent, err := replayOnce(bus, it, 1, ReplayOptions{})
+	if err != nil {
+		t.Fatalf("replayOnce: %v", err)
+	}
+	if sent != len(frames) {
+		t.Fatalf("sent = %d, want %d", sent, len(frames))
+	}
+
+	if err := it.Reset(); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+
+	sent, err = replayOnce(bus, it, 1, ReplayOptions{})
+	if err != nil {
+		t.Fatalf("replayOnce after reset: %v", err)
+	}
+	if sent != len(frames) {
+		t.Fatalf("sent after reset = %d, want %d", sent, len(frames))
+	}
+
+	if len(conn.sent) != 2*len(frames) {
+		t.Fatalf("total frames sent = %d, want %d", len(conn.sent), 2*len(frames))
+	}
+}
+
+func TestReplayFramesSpeedsUpPlayback(t *testing.T) {
+	frames := []CANFrame{
+		{Timestamp: 0, ID: 0x1},
+		{Timestamp: 200_000, ID: 0x2}, // 200ms after the first frame
+	}
+
+	conn := &recordingConn{}
+	bus := can.NewBus(conn)
+
+	start := time.Now()
+	opts := ReplayOptions{Speed: 50} // ~4ms delay instead of 200ms
+	if err := ReplayFrames(bus, &sliceIterator{frames: frames}, opts); err != nil {
+		t.Fatalf("ReplayFrames: %v", err)
+	}
+	elapsed := time.Since(start)
+
+	if elapsed > 150*time.Millisecond {
+		t.Errorf("replay took %v, expected -speed=50 to shrink the 200ms gap well below that", elapsed)
+	}
+}
+
+func TestReplayFramesNoFramesIsAnError(t *testing.T) {
+	conn := &recordingConn{}
+	bus := can.NewBus(conn)
+
+	err := ReplayFrames(bus, &sliceIterator{}, ReplayOptions{})
+	if err == nil {
+		t.Fatal("expected an error when there are no frames to replay")
+	}
+}
+
+func TestReplayFramesLoopRequiresResettable(t *testing.T) {
+	conn := &recordingConn{}
+	bus := can.NewBus(conn)
+
+	it := nonResettableIterator{&sliceIterator{frames: []CANFrame{{Timestamp: 0, ID: 0x1}}}}
+
+	err := ReplayFrames(bus, it, ReplayOptions{Loop: true})
+	if err == nil {
+		t.Fatal("expected an error when -loop is set on a non-resettable iterator")
+	}
+}
+
+// nonResettableIterator hides sliceIterator's Reset method so it no
+// longer satisfies Resettable.
+type nonResettableIterator struct {
+	it *sliceIterator
+}
+
+func (n nonResettableIterator) Next() (CANFrame, error) { return n.it.Next() }